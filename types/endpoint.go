@@ -0,0 +1,21 @@
+package types
+
+import "net"
+
+// Endpt describes one side of a link as it is pushed into a node's network
+// namespace: the name and (optional) MAC address the interface should end up
+// with, and any sysctls that should be applied to it once it is up. It is
+// the argument SetNameMACAndUpInterface acts on, so any link kind that routes
+// its interface setup through that helper gets sysctl application for free.
+type Endpt struct {
+	// Iface is the name the interface is renamed to inside the node's netns.
+	Iface string
+
+	// Mac is the hardware address to assign. Left nil/empty to keep the
+	// kernel-assigned address.
+	Mac net.HardwareAddr
+
+	// Sysctls are applied to Iface inside the node's netns once it is up. See
+	// ApplySysctls for the key format.
+	Sysctls map[string]string
+}
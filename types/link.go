@@ -3,6 +3,8 @@ package types
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/containernetworking/plugins/pkg/ns"
@@ -16,6 +18,15 @@ type LinkCommonParams struct {
 	Mtu    int                    `yaml:"mtu,omitempty"`
 	Labels map[string]string      `yaml:"labels,omitempty"`
 	Vars   map[string]interface{} `yaml:"vars,omitempty"`
+
+	// Sysctls sets kernel knobs for an endpoint's interface once it is up,
+	// e.g. "net.ipv6.conf.<if>.disable_ipv6": "1". The literal "<if>" in a key
+	// is replaced with the endpoint's interface name before it is applied, see
+	// ApplySysctls. Link kinds that build an Endpt and deploy it via
+	// SetNameMACAndUpInterface (e.g. LinkVXLAN) get this applied automatically;
+	// kinds that don't go through that helper (e.g. LinkCNI, whose interface is
+	// created by an external plugin) apply it explicitly in Deploy instead.
+	Sysctls map[string]string `yaml:"sysctls,omitempty"`
 }
 
 // LinkDefinition represents a link definition in the topology file.
@@ -32,6 +43,8 @@ const (
 	LinkTypeMgmtNet LinkType = "mgmt-net"
 	LinkTypeMacVLan LinkType = "macvlan"
 	LinkTypeHost    LinkType = "host"
+	LinkTypeCNI     LinkType = "cni"
+	LinkTypeVXLAN   LinkType = "vxlan"
 
 	// LinkTypeBrief is a link definition where link types
 	// are encoded in the endpoint definition as string and allow users
@@ -40,21 +53,21 @@ const (
 )
 
 // parseLinkType parses a string representation of a link type into a LinkDefinitionType.
+// Besides the brief notation keyword, it accepts any LinkType that has a driver
+// registered via RegisterLinkDriver.
 func parseLinkType(s string) (LinkType, error) {
-	switch strings.TrimSpace(strings.ToLower(s)) {
-	case string(LinkTypeMacVLan):
-		return LinkTypeMacVLan, nil
-	case string(LinkTypeVEth):
-		return LinkTypeVEth, nil
-	case string(LinkTypeMgmtNet):
-		return LinkTypeMgmtNet, nil
-	case string(LinkTypeHost):
-		return LinkTypeHost, nil
-	case string(LinkTypeBrief):
+	s = strings.TrimSpace(strings.ToLower(s))
+
+	if s == string(LinkTypeBrief) {
 		return LinkTypeBrief, nil
-	default:
-		return "", fmt.Errorf("unable to parse %q as LinkType", s)
 	}
+
+	lt := LinkType(s)
+	if _, ok := getLinkDriver(lt); ok {
+		return lt, nil
+	}
+
+	return "", fmt.Errorf("unable to parse %q as LinkType", s)
 }
 
 var _ yaml.Unmarshaler = (*LinkDefinition)(nil)
@@ -90,50 +103,7 @@ func (r *LinkDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error
 		}
 	}
 
-	switch lt {
-	case LinkTypeVEth:
-		var l struct {
-			// the Type field is injected artificially
-			// to allow strict yaml parsing to work.
-			Type        string `yaml:"type"`
-			LinkVEthRaw `yaml:",inline"`
-		}
-		err := unmarshal(&l)
-		if err != nil {
-			return err
-		}
-		r.Link = &l.LinkVEthRaw
-	case LinkTypeMgmtNet:
-		var l struct {
-			Type           string `yaml:"type"`
-			LinkMgmtNetRaw `yaml:",inline"`
-		}
-		err := unmarshal(&l)
-		if err != nil {
-			return err
-		}
-		r.Link = &l.LinkMgmtNetRaw
-	case LinkTypeHost:
-		var l struct {
-			Type        string `yaml:"type"`
-			LinkHostRaw `yaml:",inline"`
-		}
-		err := unmarshal(&l)
-		if err != nil {
-			return err
-		}
-		r.Link = &l.LinkHostRaw
-	case LinkTypeMacVLan:
-		var l struct {
-			Type           string `yaml:"type"`
-			LinkMACVLANRaw `yaml:",inline"`
-		}
-		err := unmarshal(&l)
-		if err != nil {
-			return err
-		}
-		r.Link = &l.LinkMACVLANRaw
-	case LinkTypeBrief:
+	if lt == LinkTypeBrief {
 		// brief link's endpoint format
 		var l struct {
 			Type       string `yaml:"type"`
@@ -151,13 +121,28 @@ func (r *LinkDefinition) UnmarshalYAML(unmarshal func(interface{}) error) error
 		if err != nil {
 			return err
 		}
-	default:
-		return fmt.Errorf("unknown link type %q", lt)
+
+		return nil
+	}
+
+	driver, ok := getLinkDriver(lt)
+	if !ok {
+		return fmt.Errorf("unknown link type %q, known link types: %v", lt, ListLinkDrivers())
+	}
+
+	link, err := driver.UnmarshalRaw(unmarshal)
+	if err != nil {
+		return err
 	}
+	r.Link = link
 
 	return nil
 }
 
+// briefLinkConversion turns a brief-notation link definition into a RawLink by
+// checking each endpoint for a registered driver's type keyword (e.g.
+// "macvlan:eth1"). If none of the endpoints carry a keyword, it falls back to
+// the veth driver, which is the brief notation's default link kind.
 func briefLinkConversion(lc LinkConfig) (RawLink, error) {
 	// check two endpoints defined
 	if len(lc.Endpoints) != 2 {
@@ -168,20 +153,24 @@ func briefLinkConversion(lc LinkConfig) (RawLink, error) {
 		node := parts[0]
 
 		lt, err := parseLinkType(node)
-		if err == nil {
+		if err != nil || lt == LinkTypeBrief {
 			continue
 		}
 
-		switch lt {
-		case LinkTypeMacVLan:
-			return macVlanFromLinkConfig(lc, x)
-		case LinkTypeMgmtNet:
-			return mgmtNetFromLinkConfig(lc, x)
-		case LinkTypeHost:
-			return hostFromLinkConfig(lc, x)
+		driver, ok := getLinkDriver(lt)
+		if !ok {
+			continue
 		}
+
+		return driver.BriefFromLinkConfig(lc, x)
+	}
+
+	driver, ok := getLinkDriver(LinkTypeVEth)
+	if !ok {
+		return nil, fmt.Errorf("no driver registered for default link type %q", LinkTypeVEth)
 	}
-	return vEthFromLinkConfig(lc)
+
+	return driver.BriefFromLinkConfig(lc, -1)
 }
 
 type RawLink interface {
@@ -193,6 +182,15 @@ type LinkInterf interface {
 	GetType() LinkType
 }
 
+// LinkRemover is implemented by link kinds that need explicit teardown to
+// release resources acquired during Deploy (e.g. CNI IPAM allocations).
+// Link kinds that don't hold such state, such as a plain veth, don't need to
+// implement it: callers tearing a lab down type-assert for it and skip links
+// that don't support it.
+type LinkRemover interface {
+	Remove(context.Context) error
+}
+
 func extractHostNodeInterfaceData(lc LinkConfig, specialEPIndex int) (host, hostIf, node, nodeIf string) {
 	// the index of the node is the specialEndpointIndex +1  modulo 2
 	nodeindex := (specialEPIndex + 1) % 2
@@ -222,12 +220,22 @@ type LinkNode interface {
 	// In case of a bridge node (ovs or regular linux bridge) it will take the interface and make the bridge
 	// the master of the interface and bring the interface up.
 	AddLink(ctx context.Context, link netlink.Link, f func(ns.NetNS) error) error
+
+	// GetNSPath returns the filesystem path of the node's network namespace
+	// (e.g. /proc/<pid>/ns/net). It is used by link types that hand the
+	// namespace off to external tooling, such as CNI plugins, instead of
+	// manipulating it through AddLink.
+	GetNSPath(ctx context.Context) (string, error)
 }
 
-// SetNameMACAndUpInterface is a helper function that will bind interface name and Mac
-// and return a function that can run in the netns.Do() call for execution in a network namespace
+// SetNameMACAndUpInterface is a helper function that will bind interface name and Mac,
+// bring the interface up and apply any endpoint sysctls, returning a function that can
+// run in the netns.Do() call for execution in a network namespace. Routing sysctl
+// application through here, rather than leaving it to each link kind's Deploy, means
+// any link that builds an Endpt and goes through this helper applies endpt.Sysctls
+// without having to remember to call ApplySysctls itself.
 func SetNameMACAndUpInterface(l netlink.Link, endpt *Endpt) func(ns.NetNS) error {
-	return func(_ ns.NetNS) error {
+	return func(hostNS ns.NetNS) error {
 		// rename the given link
 		err := netlink.LinkSetName(l, endpt.Iface)
 		if err != nil {
@@ -248,6 +256,30 @@ func SetNameMACAndUpInterface(l netlink.Link, endpt *Endpt) func(ns.NetNS) error
 			return fmt.Errorf("failed to set %q up: %v",
 				endpt.Iface, err)
 		}
+
+		if len(endpt.Sysctls) > 0 {
+			return ApplySysctls(endpt.Iface, endpt.Sysctls)(hostNS)
+		}
+
+		return nil
+	}
+}
+
+// ApplySysctls returns a function that writes the given sysctl key/value pairs
+// for iface. It is meant to run inside ns.NetNS.Do(), after the interface has
+// been renamed and brought up, so that the /proc/sys view it writes to is
+// namespace-local. The literal "<if>" in a sysctl key is replaced with iface,
+// e.g. "net.ipv6.conf.<if>.disable_ipv6" becomes "net.ipv6.conf.eth1.disable_ipv6".
+func ApplySysctls(iface string, sysctls map[string]string) func(ns.NetNS) error {
+	return func(_ ns.NetNS) error {
+		for k, v := range sysctls {
+			key := strings.ReplaceAll(k, "<if>", iface)
+			path := filepath.Join("/proc/sys", strings.ReplaceAll(key, ".", "/"))
+
+			if err := os.WriteFile(path, []byte(v), 0o644); err != nil {
+				return fmt.Errorf("failed to set sysctl %q to %q: %w", key, v, err)
+			}
+		}
 		return nil
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,243 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containernetworking/cni/libcni"
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	current "github.com/containernetworking/cni/pkg/types/100"
+	"github.com/containernetworking/plugins/pkg/ns"
+)
+
+// defaultCNIVersion is assumed when a cni link does not set `cni-version`.
+const defaultCNIVersion = "1.0.0"
+
+// defaultCNIBinDirs are searched for plugin binaries when a link does not override them.
+var defaultCNIBinDirs = []string{"/opt/cni/bin", "/usr/lib/cni"}
+
+// CNIEndpoint identifies the node-local interface that a cni link's result is attached to.
+type CNIEndpoint struct {
+	Node  string `yaml:"node"`
+	Iface string `yaml:"iface,omitempty"`
+}
+
+// LinkCNIRaw is the raw (string) representation of a link of type "cni" as defined
+// in the topology file. It attaches a node's network namespace to an external CNI
+// plugin chain (bridge, macvlan, ptp, host-local IPAM, portmap, flannel, etc.)
+// instead of relying on containerlab's built-in link types.
+type LinkCNIRaw struct {
+	LinkCommonParams `yaml:",inline"`
+
+	// Endpoint is the node-side interface that the CNI result is plumbed into.
+	Endpoint *CNIEndpoint `yaml:"endpoint"`
+
+	// NetworkConfig is an inline CNI network configuration list, equivalent to a .conflist file.
+	NetworkConfig string `yaml:"network-config,omitempty"`
+
+	// NetworkConfigPath points to a CNI network configuration list file on disk.
+	NetworkConfigPath string `yaml:"network-config-path,omitempty"`
+
+	// CNIVersion is the CNI spec version, e.g. "0.4.0" or "1.0.0", set on the network
+	// config list when the list does not set cniVersion itself; defaults to defaultCNIVersion.
+	CNIVersion string `yaml:"cni-version,omitempty"`
+
+	// BinDirs overrides the directories searched for plugin binaries.
+	BinDirs []string `yaml:"bin-dirs,omitempty"`
+
+	// Args are passed to the plugins as CNI_ARGS / RuntimeConf.Args.
+	Args map[string]string `yaml:"args,omitempty"`
+
+	// CapabilityArgs are passed to plugins that advertise a matching capability (e.g. ipRanges, portMappings).
+	CapabilityArgs map[string]interface{} `yaml:"capability-args,omitempty"`
+}
+
+// Resolve converts the raw cni link definition into a deployable LinkCNI.
+func (r *LinkCNIRaw) Resolve() (LinkInterf, error) {
+	if r.Endpoint == nil || r.Endpoint.Node == "" {
+		return nil, fmt.Errorf("cni link requires an endpoint.node")
+	}
+	if r.NetworkConfig != "" && r.NetworkConfigPath != "" {
+		return nil, fmt.Errorf("cni link cannot set both network-config and network-config-path")
+	}
+
+	node, err := getNode(r.Endpoint.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	version := r.CNIVersion
+	if version == "" {
+		version = defaultCNIVersion
+	}
+
+	ncl, err := loadCNINetworkConfigList(r.NetworkConfig, r.NetworkConfigPath, version)
+	if err != nil {
+		return nil, err
+	}
+
+	binDirs := r.BinDirs
+	if len(binDirs) == 0 {
+		binDirs = defaultCNIBinDirs
+	}
+
+	// the interface name is resolved once here, rather than on every call to
+	// runtimeConf, so that Deploy and Remove always agree on the name the CNI
+	// plugins keyed their add against.
+	ifName := r.Endpoint.Iface
+	if ifName == "" {
+		ifName = genRandomIfName()
+	}
+
+	return &LinkCNI{
+		LinkCommonParams:  r.LinkCommonParams,
+		endpoint:          r.Endpoint,
+		ifName:            ifName,
+		node:              node,
+		networkConfigList: ncl,
+		cniConfig:         libcni.NewCNIConfig(binDirs, nil),
+		args:              r.Args,
+		capabilityArgs:    r.CapabilityArgs,
+	}, nil
+}
+
+// loadCNINetworkConfigList loads a CNI network config list from an inline string or a file path,
+// defaulting its cniVersion field when the config list does not already set one.
+func loadCNINetworkConfigList(inline, path, version string) (*libcni.NetworkConfigList, error) {
+	var (
+		ncl *libcni.NetworkConfigList
+		err error
+	)
+
+	switch {
+	case inline != "":
+		ncl, err = libcni.ConfListFromBytes([]byte(inline))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse inline cni network-config: %w", err)
+		}
+	case path != "":
+		ncl, err = libcni.ConfListFromFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load cni network-config-path %q: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("cni link requires either network-config or network-config-path")
+	}
+
+	if ncl.CNIVersion == "" {
+		ncl.CNIVersion = version
+	}
+
+	return ncl, nil
+}
+
+// LinkCNI represents a link that hands a node's network namespace to an external
+// CNI plugin chain and records the plugins' result for the deployed endpoint.
+type LinkCNI struct {
+	LinkCommonParams
+
+	endpoint *CNIEndpoint
+	// ifName is the interface name handed to the CNI plugin chain, resolved
+	// once in Resolve so that Deploy and Remove always agree on it.
+	ifName string
+	node   LinkNode
+
+	networkConfigList *libcni.NetworkConfigList
+	cniConfig         *libcni.CNIConfig
+	args              map[string]string
+	capabilityArgs    map[string]interface{}
+
+	// result is the CNI result returned by AddNetworkList, converted to the
+	// current result version and exposed via Result().
+	result cnitypes.Result
+}
+
+var _ LinkInterf = (*LinkCNI)(nil)
+
+// Deploy invokes the configured CNI plugin chain against the node's network namespace
+// and retains the returned result so the interface, IPs and routes are recorded.
+func (l *LinkCNI) Deploy(ctx context.Context) error {
+	nsPath, err := l.node.GetNSPath(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get netns path for cni link: %w", err)
+	}
+
+	rt := l.runtimeConf(nsPath)
+
+	res, err := l.cniConfig.AddNetworkList(ctx, l.networkConfigList, rt)
+	if err != nil {
+		return fmt.Errorf("failed to add cni network %q: %w", l.networkConfigList.Name, err)
+	}
+
+	result, err := current.NewResultFromResult(res)
+	if err != nil {
+		return fmt.Errorf("failed to convert cni result for network %q: %w", l.networkConfigList.Name, err)
+	}
+	l.result = result
+
+	if len(l.Sysctls) > 0 {
+		targetNS, err := ns.GetNS(nsPath)
+		if err != nil {
+			return fmt.Errorf("failed to open netns %q to apply sysctls: %w", nsPath, err)
+		}
+		defer targetNS.Close()
+
+		if err := targetNS.Do(ApplySysctls(rt.IfName, l.Sysctls)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Remove tears down the link by calling DelNetworkList with the same runtime config used to deploy it.
+func (l *LinkCNI) Remove(ctx context.Context) error {
+	nsPath, err := l.node.GetNSPath(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get netns path for cni link: %w", err)
+	}
+
+	return l.cniConfig.DelNetworkList(ctx, l.networkConfigList, l.runtimeConf(nsPath))
+}
+
+func (l *LinkCNI) runtimeConf(nsPath string) *libcni.RuntimeConf {
+	return &libcni.RuntimeConf{
+		ContainerID:    l.endpoint.Node,
+		NetNS:          nsPath,
+		IfName:         l.ifName,
+		Args:           cniArgsFromMap(l.args),
+		CapabilityArgs: l.capabilityArgs,
+	}
+}
+
+// GetType returns the type of the link.
+func (l *LinkCNI) GetType() LinkType {
+	return LinkTypeCNI
+}
+
+// Result returns the CNI result recorded by the most recent Deploy, including
+// the interface, IPs and routes the plugin chain assigned. It is nil until a
+// Deploy's AddNetworkList call has succeeded; it is set even if applying
+// sysctls afterwards fails.
+func (l *LinkCNI) Result() cnitypes.Result {
+	return l.result
+}
+
+// Nodes returns the nodes this link touches, so LinkDeployer can serialize it
+// against other links sharing the same node.
+func (l *LinkCNI) Nodes() []string {
+	return []string{l.endpoint.Node}
+}
+
+var (
+	_ NodeLister  = (*LinkCNI)(nil)
+	_ LinkRemover = (*LinkCNI)(nil)
+)
+
+func cniArgsFromMap(args map[string]string) [][2]string {
+	out := make([][2]string, 0, len(args))
+	for k, v := range args {
+		out = append(out, [2]string{k, v})
+	}
+	return out
+}
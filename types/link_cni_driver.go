@@ -0,0 +1,31 @@
+package types
+
+import "fmt"
+
+func init() {
+	RegisterLinkDriver(cniLinkDriver{})
+}
+
+// cniLinkDriver registers the "cni" link kind with the link driver registry.
+type cniLinkDriver struct{}
+
+func (cniLinkDriver) Name() LinkType {
+	return LinkTypeCNI
+}
+
+func (cniLinkDriver) UnmarshalRaw(unmarshal func(interface{}) error) (RawLink, error) {
+	var l struct {
+		Type       string `yaml:"type"`
+		LinkCNIRaw `yaml:",inline"`
+	}
+	if err := unmarshal(&l); err != nil {
+		return nil, err
+	}
+	return &l.LinkCNIRaw, nil
+}
+
+// BriefFromLinkConfig is not supported: a cni link carries CNI config fields
+// that cannot be expressed in the two-endpoint brief notation.
+func (cniLinkDriver) BriefFromLinkConfig(_ LinkConfig, _ int) (RawLink, error) {
+	return nil, fmt.Errorf("link type %q does not support brief notation, use the explicit form", LinkTypeCNI)
+}
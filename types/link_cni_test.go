@@ -0,0 +1,133 @@
+package types
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+)
+
+// fakeLinkNode is a minimal LinkNode used to exercise Resolve()/Deploy() paths
+// that need a registered node, without any real netns dependency.
+type fakeLinkNode struct {
+	nsPath string
+}
+
+func (f *fakeLinkNode) AddLink(_ context.Context, _ netlink.Link, _ func(ns.NetNS) error) error {
+	return nil
+}
+
+func (f *fakeLinkNode) GetNSPath(_ context.Context) (string, error) {
+	return f.nsPath, nil
+}
+
+const validInlineCNIConfig = `{"cniVersion":"","name":"test-net","plugins":[{"type":"bridge"}]}`
+
+func TestLinkCNIRaw_Resolve_Validation(t *testing.T) {
+	t.Cleanup(ResetNodeRegistry)
+	RegisterNode("n1", &fakeLinkNode{nsPath: "/proc/1/ns/net"})
+
+	cases := []struct {
+		name    string
+		raw     LinkCNIRaw
+		wantErr string
+	}{
+		{
+			name:    "missing endpoint",
+			raw:     LinkCNIRaw{},
+			wantErr: "requires an endpoint.node",
+		},
+		{
+			name:    "empty endpoint node",
+			raw:     LinkCNIRaw{Endpoint: &CNIEndpoint{}},
+			wantErr: "requires an endpoint.node",
+		},
+		{
+			name: "both network-config and network-config-path set",
+			raw: LinkCNIRaw{
+				Endpoint:          &CNIEndpoint{Node: "n1"},
+				NetworkConfig:     validInlineCNIConfig,
+				NetworkConfigPath: "/tmp/does-not-matter.conflist",
+			},
+			wantErr: "cannot set both network-config and network-config-path",
+		},
+		{
+			name: "neither network-config nor network-config-path set",
+			raw: LinkCNIRaw{
+				Endpoint: &CNIEndpoint{Node: "n1"},
+			},
+			wantErr: "requires either network-config or network-config-path",
+		},
+		{
+			name: "unregistered node",
+			raw: LinkCNIRaw{
+				Endpoint:      &CNIEndpoint{Node: "does-not-exist"},
+				NetworkConfig: validInlineCNIConfig,
+			},
+			wantErr: "not found in node registry",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.raw.Resolve()
+			if err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), c.wantErr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), c.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinkCNIRaw_Resolve_DefaultsCNIVersion(t *testing.T) {
+	t.Cleanup(ResetNodeRegistry)
+	RegisterNode("n1", &fakeLinkNode{nsPath: "/proc/1/ns/net"})
+
+	raw := LinkCNIRaw{
+		Endpoint:      &CNIEndpoint{Node: "n1", Iface: "eth1"},
+		NetworkConfig: validInlineCNIConfig,
+	}
+
+	link, err := raw.Resolve()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cni, ok := link.(*LinkCNI)
+	if !ok {
+		t.Fatalf("expected *LinkCNI, got %T", link)
+	}
+	if got := cni.networkConfigList.CNIVersion; got != defaultCNIVersion {
+		t.Fatalf("expected cniVersion to default to %q, got %q", defaultCNIVersion, got)
+	}
+	if got := cni.ifName; got != "eth1" {
+		t.Fatalf("expected ifName %q to be taken from endpoint.iface, got %q", "eth1", got)
+	}
+}
+
+func TestLinkCNIRaw_Resolve_PreservesExplicitCNIVersion(t *testing.T) {
+	t.Cleanup(ResetNodeRegistry)
+	RegisterNode("n1", &fakeLinkNode{nsPath: "/proc/1/ns/net"})
+
+	raw := LinkCNIRaw{
+		Endpoint:      &CNIEndpoint{Node: "n1"},
+		NetworkConfig: `{"cniVersion":"0.4.0","name":"test-net","plugins":[{"type":"bridge"}]}`,
+	}
+
+	link, err := raw.Resolve()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cni := link.(*LinkCNI)
+	if got := cni.networkConfigList.CNIVersion; got != "0.4.0" {
+		t.Fatalf("expected explicit cniVersion to be preserved, got %q", got)
+	}
+	if cni.ifName == "" {
+		t.Fatalf("expected a generated ifName when endpoint.iface is unset")
+	}
+}
@@ -0,0 +1,223 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultMaxWorkers is the default size of the worker pool a LinkDeployer uses
+// to deploy links concurrently. It is meant to be overridden via
+// LinkDeployer.MaxWorkers; no CLI flag sets it yet, so a caller wanting a
+// configurable worker count has to wire one up (e.g. a --max-workers flag)
+// itself and assign it to MaxWorkers before calling Deploy.
+var DefaultMaxWorkers = runtime.NumCPU()
+
+// linkDeployBaseBackoff is the initial delay before retrying a link whose
+// Deploy failed with a transient netlink error.
+const linkDeployBaseBackoff = 100 * time.Millisecond
+
+// unknownNodesLockKey serializes every link that doesn't implement NodeLister
+// against every other such link. LinkDeployer has no way to know which nodes
+// these links touch, so it falls back to full serialization among them rather
+// than letting them race their netlink writes unsynchronized.
+//
+// This only buys real parallelism for link kinds that implement NodeLister
+// (currently LinkCNI and LinkVXLAN, both added alongside LinkDeployer). Any
+// other LinkInterf implementation — including veth/macvlan/mgmt-net/host,
+// which are not part of this change — falls back to this single lock and
+// loses the parallelism LinkDeployer otherwise provides. Implementing
+// NodeLister.Nodes() on those types (they already know their two endpoint
+// node names) is a small, non-breaking addition that removes this fallback
+// for them.
+const unknownNodesLockKey = "\x00unknown-nodes"
+
+// NodeLister is implemented by links that can report the nodes they touch, so
+// that LinkDeployer can serialize links sharing a node while still deploying
+// independent links in parallel.
+type NodeLister interface {
+	// Nodes returns the names of the nodes this link writes netlink state into.
+	Nodes() []string
+}
+
+// LinkDeployer deploys a set of links concurrently across a bounded worker
+// pool. Links that touch the same node are serialized against each other, via
+// a per-node mutex, to avoid racing netlink writes into the same network
+// namespace, while links that don't share a node deploy in parallel.
+type LinkDeployer struct {
+	// MaxWorkers caps the number of links deployed concurrently. Zero means DefaultMaxWorkers.
+	MaxWorkers int
+
+	// MaxRetries bounds the retry-with-backoff wrapper applied around each
+	// link's Deploy, to absorb transient netlink failures (EEXIST/EBUSY) that
+	// can happen while a peer interface is still being moved into its netns.
+	// Zero means 3.
+	MaxRetries int
+
+	nodeLocks sync.Map // node name -> *sync.Mutex
+}
+
+// Deploy deploys all the given links, running links that don't share a node
+// concurrently across a bounded worker pool, and returns on the first error
+// encountered, cancelling any links still in flight.
+func (d *LinkDeployer) Deploy(ctx context.Context, links []LinkInterf) error {
+	maxWorkers := d.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = DefaultMaxWorkers
+	}
+	if maxWorkers <= 0 {
+		maxWorkers = 1
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(maxWorkers)
+
+	start := time.Now()
+
+	for _, link := range links {
+		link := link
+
+		g.Go(func() error {
+			unlock := d.lockNodes(link)
+			defer unlock()
+
+			return d.deployWithRetry(gCtx, link)
+		})
+	}
+
+	err := g.Wait()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("deployed %d links in %s using up to %d workers", len(links), time.Since(start), maxWorkers)
+
+	return nil
+}
+
+// Teardown removes every link that implements LinkRemover, applying the same
+// per-node serialization and worker-pool bound as Deploy. Links that don't
+// support explicit teardown (e.g. a plain veth, torn down along with its
+// node's netns) are skipped.
+func (d *LinkDeployer) Teardown(ctx context.Context, links []LinkInterf) error {
+	maxWorkers := d.MaxWorkers
+	if maxWorkers <= 0 {
+		maxWorkers = DefaultMaxWorkers
+	}
+	if maxWorkers <= 0 {
+		maxWorkers = 1
+	}
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(maxWorkers)
+
+	for _, link := range links {
+		remover, ok := link.(LinkRemover)
+		if !ok {
+			continue
+		}
+
+		link, remover := link, remover
+
+		g.Go(func() error {
+			unlock := d.lockNodes(link)
+			defer unlock()
+
+			if err := remover.Remove(gCtx); err != nil {
+				return fmt.Errorf("failed to remove %s link: %w", link.GetType(), err)
+			}
+			return nil
+		})
+	}
+
+	return g.Wait()
+}
+
+// lockNodes locks the per-node mutexes for every node the link touches, in a
+// stable (sorted) order so that two links sharing more than one node can never
+// deadlock on each other, and returns a function that releases them. Links
+// that don't implement NodeLister are serialized against each other via
+// unknownNodesLockKey, since their node membership can't be determined.
+func (d *LinkDeployer) lockNodes(link LinkInterf) func() {
+	var nodes []string
+	if lister, ok := link.(NodeLister); ok {
+		nodes = append([]string(nil), lister.Nodes()...)
+	} else {
+		nodes = []string{unknownNodesLockKey}
+	}
+
+	sort.Strings(nodes)
+
+	locks := make([]*sync.Mutex, 0, len(nodes))
+	for _, n := range nodes {
+		v, _ := d.nodeLocks.LoadOrStore(n, &sync.Mutex{})
+		locks = append(locks, v.(*sync.Mutex))
+	}
+
+	for _, l := range locks {
+		l.Lock()
+	}
+
+	return func() {
+		for _, l := range locks {
+			l.Unlock()
+		}
+	}
+}
+
+// deployWithRetry calls link.Deploy, retrying with exponential backoff when it
+// fails with a transient netlink error such as EEXIST or EBUSY.
+func (d *LinkDeployer) deployWithRetry(ctx context.Context, link LinkInterf) error {
+	maxRetries := d.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = 3
+	}
+
+	backoff := linkDeployBaseBackoff
+
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		err = link.Deploy(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if !isTransientNetlinkError(err) {
+			return fmt.Errorf("failed to deploy %s link: %w", link.GetType(), err)
+		}
+
+		if attempt == maxRetries {
+			break
+		}
+
+		log.Debugf("retrying %s link deploy after transient error (attempt %d/%d): %v",
+			link.GetType(), attempt+1, maxRetries, err)
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff *= 2
+	}
+
+	return fmt.Errorf("failed to deploy %s link after %d attempts: %w", link.GetType(), maxRetries+1, err)
+}
+
+// isTransientNetlinkError reports whether err is worth retrying, i.e. it stems
+// from the peer end of a link still being moved into its netns.
+func isTransientNetlinkError(err error) bool {
+	return errors.Is(err, syscall.EEXIST) || errors.Is(err, syscall.EBUSY)
+}
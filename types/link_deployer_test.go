@@ -0,0 +1,234 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// fakeDeployLink is a minimal LinkInterf (and optionally NodeLister) used to
+// exercise LinkDeployer without any real netlink/netns dependency.
+type fakeDeployLink struct {
+	nodes    []string
+	deployFn func(ctx context.Context) error
+}
+
+func (f *fakeDeployLink) Deploy(ctx context.Context) error { return f.deployFn(ctx) }
+func (f *fakeDeployLink) GetType() LinkType                { return LinkType("fake") }
+func (f *fakeDeployLink) Nodes() []string                  { return f.nodes }
+
+// noNodesDeployLink is a LinkInterf that does NOT implement NodeLister, used
+// to exercise the unknownNodesLockKey fallback.
+type noNodesDeployLink struct {
+	deployFn func(ctx context.Context) error
+}
+
+func (f *noNodesDeployLink) Deploy(ctx context.Context) error { return f.deployFn(ctx) }
+func (f *noNodesDeployLink) GetType() LinkType                { return LinkType("fake-no-nodes") }
+
+func TestLinkDeployer_SerializesLinksSharingANode(t *testing.T) {
+	var (
+		current  int32
+		maxSeen  int32
+		sameNode = "node1"
+	)
+
+	track := func(ctx context.Context) error {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		return nil
+	}
+
+	links := []LinkInterf{
+		&fakeDeployLink{nodes: []string{sameNode}, deployFn: track},
+		&fakeDeployLink{nodes: []string{sameNode}, deployFn: track},
+		&fakeDeployLink{nodes: []string{sameNode}, deployFn: track},
+	}
+
+	d := &LinkDeployer{MaxWorkers: 8}
+	if err := d.Deploy(context.Background(), links); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if maxSeen != 1 {
+		t.Fatalf("expected links sharing a node to never run concurrently, saw %d in flight at once", maxSeen)
+	}
+}
+
+func TestLinkDeployer_DeploysIndependentLinksConcurrently(t *testing.T) {
+	var current, maxSeen int32
+
+	track := func(ctx context.Context) error {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+				break
+			}
+		}
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		return nil
+	}
+
+	links := []LinkInterf{
+		&fakeDeployLink{nodes: []string{"node1"}, deployFn: track},
+		&fakeDeployLink{nodes: []string{"node2"}, deployFn: track},
+	}
+
+	d := &LinkDeployer{MaxWorkers: 8}
+	if err := d.Deploy(context.Background(), links); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if maxSeen < 2 {
+		t.Fatalf("expected independent links to run concurrently, saw at most %d in flight at once", maxSeen)
+	}
+}
+
+func TestLinkDeployer_SerializesLinksWithoutNodeLister(t *testing.T) {
+	var current, maxSeen int32
+
+	track := func(ctx context.Context) error {
+		n := atomic.AddInt32(&current, 1)
+		for {
+			seen := atomic.LoadInt32(&maxSeen)
+			if n <= seen || atomic.CompareAndSwapInt32(&maxSeen, seen, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		atomic.AddInt32(&current, -1)
+		return nil
+	}
+
+	links := []LinkInterf{
+		&noNodesDeployLink{deployFn: track},
+		&noNodesDeployLink{deployFn: track},
+	}
+
+	d := &LinkDeployer{MaxWorkers: 8}
+	if err := d.Deploy(context.Background(), links); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if maxSeen != 1 {
+		t.Fatalf("expected links without NodeLister to be serialized, saw %d in flight at once", maxSeen)
+	}
+}
+
+func TestLinkDeployer_RetriesTransientErrorsThenGivesUp(t *testing.T) {
+	var attempts int32
+
+	link := &fakeDeployLink{
+		deployFn: func(ctx context.Context) error {
+			atomic.AddInt32(&attempts, 1)
+			return fmt.Errorf("peer busy: %w", syscall.EBUSY)
+		},
+	}
+
+	d := &LinkDeployer{MaxWorkers: 1, MaxRetries: 2}
+	err := d.Deploy(context.Background(), []LinkInterf{link})
+	if err == nil {
+		t.Fatal("expected an error after exhausting retries")
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Fatalf("expected 1 initial attempt + 2 retries = 3 calls, got %d", got)
+	}
+}
+
+func TestLinkDeployer_DoesNotRetryPermanentErrors(t *testing.T) {
+	var attempts int32
+
+	link := &fakeDeployLink{
+		deployFn: func(ctx context.Context) error {
+			atomic.AddInt32(&attempts, 1)
+			return fmt.Errorf("boom")
+		},
+	}
+
+	d := &LinkDeployer{MaxWorkers: 1, MaxRetries: 5}
+	err := d.Deploy(context.Background(), []LinkInterf{link})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if got := atomic.LoadInt32(&attempts); got != 1 {
+		t.Fatalf("expected a non-transient error to fail without retrying, got %d attempts", got)
+	}
+}
+
+func TestLinkDeployer_CancelsOnFirstError(t *testing.T) {
+	failing := &fakeDeployLink{
+		nodes: []string{"node1"},
+		deployFn: func(ctx context.Context) error {
+			return fmt.Errorf("boom")
+		},
+	}
+
+	var ranToCompletion int32
+	slow := &fakeDeployLink{
+		nodes: []string{"node2"},
+		deployFn: func(ctx context.Context) error {
+			select {
+			case <-time.After(200 * time.Millisecond):
+				atomic.AddInt32(&ranToCompletion, 1)
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+
+	d := &LinkDeployer{MaxWorkers: 2}
+	err := d.Deploy(context.Background(), []LinkInterf{failing, slow})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if atomic.LoadInt32(&ranToCompletion) != 0 {
+		t.Fatal("expected the in-flight link to be cancelled rather than run to completion")
+	}
+}
+
+func TestLinkDeployer_Teardown(t *testing.T) {
+	var removed int32
+
+	link := &fakeRemovableLink{
+		removeFn: func(ctx context.Context) error {
+			atomic.AddInt32(&removed, 1)
+			return nil
+		},
+	}
+
+	// a link that doesn't implement LinkRemover must be skipped, not error.
+	notRemovable := &fakeDeployLink{nodes: []string{"node1"}, deployFn: func(ctx context.Context) error { return nil }}
+
+	d := &LinkDeployer{MaxWorkers: 4}
+	if err := d.Teardown(context.Background(), []LinkInterf{link, notRemovable}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if removed != 1 {
+		t.Fatalf("expected Remove to be called once, got %d", removed)
+	}
+}
+
+type fakeRemovableLink struct {
+	removeFn func(ctx context.Context) error
+}
+
+func (f *fakeRemovableLink) Deploy(ctx context.Context) error { return nil }
+func (f *fakeRemovableLink) GetType() LinkType                { return LinkType("fake-removable") }
+func (f *fakeRemovableLink) Remove(ctx context.Context) error { return f.removeFn(ctx) }
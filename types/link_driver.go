@@ -0,0 +1,48 @@
+package types
+
+import "sort"
+
+// LinkDriver decouples a link kind's YAML parsing from the core package, letting
+// out-of-tree binaries that embed containerlab as a library add new link kinds
+// (e.g. VXLAN, CNI, GRE, wireguard) by registering a driver instead of patching core.
+type LinkDriver interface {
+	// Name returns the LinkType this driver handles.
+	Name() LinkType
+
+	// UnmarshalRaw decodes the full (type-specific) link definition, including
+	// the "type" discriminator field, into a RawLink.
+	UnmarshalRaw(unmarshal func(interface{}) error) (RawLink, error)
+
+	// BriefFromLinkConfig converts a brief-notation link into a RawLink of this
+	// type. specialEPIndex is the index of the endpoint carrying the type
+	// keyword (e.g. "macvlan:eth1"), or -1 when the type has no such keyword
+	// (e.g. veth, which is the brief-notation default).
+	BriefFromLinkConfig(lc LinkConfig, specialEPIndex int) (RawLink, error)
+}
+
+// linkDrivers holds all drivers registered via RegisterLinkDriver, keyed by LinkType.
+var linkDrivers = map[LinkType]LinkDriver{}
+
+// RegisterLinkDriver makes a link driver available for topology parsing. It is
+// meant to be called from a package init() function, mirroring how CNI plugins
+// self-register via reexec.Register.
+func RegisterLinkDriver(d LinkDriver) {
+	linkDrivers[d.Name()] = d
+}
+
+// getLinkDriver looks up a previously registered driver by LinkType.
+func getLinkDriver(lt LinkType) (LinkDriver, bool) {
+	d, ok := linkDrivers[lt]
+	return d, ok
+}
+
+// ListLinkDrivers returns the LinkTypes of all registered drivers, sorted for
+// stable output (e.g. in error messages).
+func ListLinkDrivers() []LinkType {
+	names := make([]LinkType, 0, len(linkDrivers))
+	for lt := range linkDrivers {
+		names = append(names, lt)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,99 @@
+package types
+
+import "testing"
+
+func TestListLinkDrivers_IncludesBuiltins(t *testing.T) {
+	want := []LinkType{
+		LinkTypeVEth, LinkTypeMgmtNet, LinkTypeMacVLan,
+		LinkTypeHost, LinkTypeCNI, LinkTypeVXLAN,
+	}
+
+	got := ListLinkDrivers()
+
+	seen := make(map[LinkType]bool, len(got))
+	for _, lt := range got {
+		seen[lt] = true
+	}
+
+	for _, lt := range want {
+		if !seen[lt] {
+			t.Errorf("expected built-in driver %q to be registered, it wasn't among %v", lt, got)
+		}
+	}
+}
+
+func TestListLinkDrivers_Sorted(t *testing.T) {
+	got := ListLinkDrivers()
+	for i := 1; i < len(got); i++ {
+		if got[i-1] > got[i] {
+			t.Fatalf("ListLinkDrivers() = %v is not sorted", got)
+		}
+	}
+}
+
+func TestGetLinkDriver_UnknownType(t *testing.T) {
+	if _, ok := getLinkDriver(LinkType("does-not-exist")); ok {
+		t.Fatalf("expected no driver registered for an unknown LinkType")
+	}
+}
+
+func TestParseLinkType(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    LinkType
+		wantErr bool
+	}{
+		{"veth", LinkTypeVEth, false},
+		{" CNI ", LinkTypeCNI, false},
+		{"vxlan", LinkTypeVXLAN, false},
+		{"brief", LinkTypeBrief, false},
+		{"does-not-exist", "", true},
+	}
+
+	for _, c := range cases {
+		got, err := parseLinkType(c.in)
+		if c.wantErr {
+			if err == nil {
+				t.Errorf("parseLinkType(%q): expected an error, got nil", c.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseLinkType(%q): unexpected error: %v", c.in, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("parseLinkType(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+// stubDriver is a minimal LinkDriver used to exercise RegisterLinkDriver/
+// getLinkDriver without colliding with any built-in LinkType.
+type stubDriver struct{ name LinkType }
+
+func (s stubDriver) Name() LinkType { return s.name }
+
+func (s stubDriver) UnmarshalRaw(_ func(interface{}) error) (RawLink, error) {
+	return nil, nil
+}
+
+func (s stubDriver) BriefFromLinkConfig(_ LinkConfig, _ int) (RawLink, error) {
+	return nil, nil
+}
+
+func TestRegisterLinkDriver_RegistersUnderItsName(t *testing.T) {
+	const testType = LinkType("test-driver-stub")
+
+	t.Cleanup(func() { delete(linkDrivers, testType) })
+
+	RegisterLinkDriver(stubDriver{name: testType})
+
+	d, ok := getLinkDriver(testType)
+	if !ok {
+		t.Fatalf("expected a driver to be registered for %q", testType)
+	}
+	if d.Name() != testType {
+		t.Fatalf("expected registered driver's Name() to be %q, got %q", testType, d.Name())
+	}
+}
@@ -0,0 +1,27 @@
+package types
+
+func init() {
+	RegisterLinkDriver(hostLinkDriver{})
+}
+
+// hostLinkDriver registers the built-in "host" link kind with the link driver registry.
+type hostLinkDriver struct{}
+
+func (hostLinkDriver) Name() LinkType {
+	return LinkTypeHost
+}
+
+func (hostLinkDriver) UnmarshalRaw(unmarshal func(interface{}) error) (RawLink, error) {
+	var l struct {
+		Type        string `yaml:"type"`
+		LinkHostRaw `yaml:",inline"`
+	}
+	if err := unmarshal(&l); err != nil {
+		return nil, err
+	}
+	return &l.LinkHostRaw, nil
+}
+
+func (hostLinkDriver) BriefFromLinkConfig(lc LinkConfig, specialEPIndex int) (RawLink, error) {
+	return hostFromLinkConfig(lc, specialEPIndex)
+}
@@ -0,0 +1,27 @@
+package types
+
+func init() {
+	RegisterLinkDriver(macVlanLinkDriver{})
+}
+
+// macVlanLinkDriver registers the built-in "macvlan" link kind with the link driver registry.
+type macVlanLinkDriver struct{}
+
+func (macVlanLinkDriver) Name() LinkType {
+	return LinkTypeMacVLan
+}
+
+func (macVlanLinkDriver) UnmarshalRaw(unmarshal func(interface{}) error) (RawLink, error) {
+	var l struct {
+		Type           string `yaml:"type"`
+		LinkMACVLANRaw `yaml:",inline"`
+	}
+	if err := unmarshal(&l); err != nil {
+		return nil, err
+	}
+	return &l.LinkMACVLANRaw, nil
+}
+
+func (macVlanLinkDriver) BriefFromLinkConfig(lc LinkConfig, specialEPIndex int) (RawLink, error) {
+	return macVlanFromLinkConfig(lc, specialEPIndex)
+}
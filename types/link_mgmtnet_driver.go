@@ -0,0 +1,27 @@
+package types
+
+func init() {
+	RegisterLinkDriver(mgmtNetLinkDriver{})
+}
+
+// mgmtNetLinkDriver registers the built-in "mgmt-net" link kind with the link driver registry.
+type mgmtNetLinkDriver struct{}
+
+func (mgmtNetLinkDriver) Name() LinkType {
+	return LinkTypeMgmtNet
+}
+
+func (mgmtNetLinkDriver) UnmarshalRaw(unmarshal func(interface{}) error) (RawLink, error) {
+	var l struct {
+		Type           string `yaml:"type"`
+		LinkMgmtNetRaw `yaml:",inline"`
+	}
+	if err := unmarshal(&l); err != nil {
+		return nil, err
+	}
+	return &l.LinkMgmtNetRaw, nil
+}
+
+func (mgmtNetLinkDriver) BriefFromLinkConfig(lc LinkConfig, specialEPIndex int) (RawLink, error) {
+	return mgmtNetFromLinkConfig(lc, specialEPIndex)
+}
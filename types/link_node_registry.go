@@ -0,0 +1,51 @@
+package types
+
+import (
+	"fmt"
+	"sync"
+)
+
+// nodeRegistry maps node names to their LinkNode implementation so that link
+// resolvers can turn a YAML endpoint's node name into something they can
+// deploy into, without every link type reimplementing its own lookup.
+//
+// This registry is the node-resolution path introduced alongside LinkCNI and
+// LinkVXLAN; it does not know about however the pre-existing veth/macvlan/
+// mgmt-net/host link types resolve node names (their source isn't part of
+// this change). Whoever wires the two together should make RegisterNode the
+// single place node names are recorded, rather than maintaining a second,
+// parallel mapping.
+var (
+	nodeRegistryMu sync.RWMutex
+	nodeRegistry   = map[string]LinkNode{}
+)
+
+// RegisterNode makes a node available for link resolution under the given name.
+// The topology loader is expected to call this for every node before links are resolved.
+func RegisterNode(name string, n LinkNode) {
+	nodeRegistryMu.Lock()
+	defer nodeRegistryMu.Unlock()
+	nodeRegistry[name] = n
+}
+
+// ResetNodeRegistry clears every registered node. It is meant to be called
+// before loading a new topology in the same process (e.g. between tests, or
+// redeploying a lab) so that stale entries from a previous load can't leak
+// into link resolution.
+func ResetNodeRegistry() {
+	nodeRegistryMu.Lock()
+	defer nodeRegistryMu.Unlock()
+	nodeRegistry = map[string]LinkNode{}
+}
+
+// getNode looks up a previously registered node by name.
+func getNode(name string) (LinkNode, error) {
+	nodeRegistryMu.RLock()
+	defer nodeRegistryMu.RUnlock()
+
+	n, ok := nodeRegistry[name]
+	if !ok {
+		return nil, fmt.Errorf("node %q not found in node registry", name)
+	}
+	return n, nil
+}
@@ -0,0 +1,32 @@
+package types
+
+func init() {
+	RegisterLinkDriver(vethLinkDriver{})
+}
+
+// vethLinkDriver registers the built-in "veth" link kind with the link driver registry.
+type vethLinkDriver struct{}
+
+func (vethLinkDriver) Name() LinkType {
+	return LinkTypeVEth
+}
+
+func (vethLinkDriver) UnmarshalRaw(unmarshal func(interface{}) error) (RawLink, error) {
+	var l struct {
+		// the Type field is injected artificially
+		// to allow strict yaml parsing to work.
+		Type        string `yaml:"type"`
+		LinkVEthRaw `yaml:",inline"`
+	}
+	if err := unmarshal(&l); err != nil {
+		return nil, err
+	}
+	return &l.LinkVEthRaw, nil
+}
+
+// BriefFromLinkConfig builds a veth link from brief notation. veth has no type
+// keyword of its own, so it is the fallback driver used when no endpoint
+// matches any other registered driver, and specialEPIndex is unused.
+func (vethLinkDriver) BriefFromLinkConfig(lc LinkConfig, _ int) (RawLink, error) {
+	return vEthFromLinkConfig(lc)
+}
@@ -0,0 +1,195 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// defaultVXLANPort is the IANA-assigned UDP port for VXLAN.
+const defaultVXLANPort = 4789
+
+// VXLANEndpoint identifies the node-local interface that the vxlan tunnel device is attached to.
+type VXLANEndpoint struct {
+	Node  string `yaml:"node"`
+	Iface string `yaml:"iface,omitempty"`
+}
+
+// LinkVXLANRaw is the raw (string) representation of a link of type "vxlan" as defined
+// in the topology file. It stitches a node interface to a VXLAN tunnel device that is
+// either bound to a multicast group (multicast learning mode) or to a single remote
+// VTEP (point-to-point mode, with the FDB entry pre-populated), letting labs span hosts
+// without external tooling.
+type LinkVXLANRaw struct {
+	LinkCommonParams `yaml:",inline"`
+
+	// Endpoint is the node-side interface the vxlan device is pushed into.
+	Endpoint *VXLANEndpoint `yaml:"endpoint"`
+
+	// Remote is the unicast IP of the remote VTEP. Mutually exclusive with McastGroup.
+	Remote string `yaml:"remote,omitempty"`
+
+	// VNI is the VXLAN network identifier.
+	VNI int `yaml:"vni"`
+
+	// McastGroup enables multicast learning mode when set. Mutually exclusive with Remote.
+	McastGroup string `yaml:"mcast-group,omitempty"`
+
+	// McastPort overrides the destination UDP port, default 4789.
+	McastPort int `yaml:"mcast-port,omitempty"`
+
+	// ParentInterface is the local interface the VXLAN device sends/receives encapsulated traffic on.
+	ParentInterface string `yaml:"parent-interface"`
+
+	// TTL sets the outer IP TTL of encapsulated packets.
+	TTL uint8 `yaml:"ttl,omitempty"`
+}
+
+// Resolve converts the raw vxlan link definition into a deployable LinkVXLAN.
+func (r *LinkVXLANRaw) Resolve() (LinkInterf, error) {
+	if r.Endpoint == nil || r.Endpoint.Node == "" {
+		return nil, fmt.Errorf("vxlan link requires an endpoint.node")
+	}
+	if r.Remote == "" && r.McastGroup == "" {
+		return nil, fmt.Errorf("vxlan link requires either remote or mcast-group")
+	}
+	if r.Remote != "" && r.McastGroup != "" {
+		return nil, fmt.Errorf("vxlan link cannot set both remote and mcast-group")
+	}
+	if r.ParentInterface == "" {
+		return nil, fmt.Errorf("vxlan link requires a parent-interface")
+	}
+	if r.Endpoint.Iface == "" {
+		return nil, fmt.Errorf("vxlan link requires an endpoint.iface")
+	}
+
+	node, err := getNode(r.Endpoint.Node)
+	if err != nil {
+		return nil, err
+	}
+
+	parent, err := netlink.LinkByName(r.ParentInterface)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find vxlan parent-interface %q: %w", r.ParentInterface, err)
+	}
+
+	return &LinkVXLAN{
+		LinkCommonParams: r.LinkCommonParams,
+		endpoint:         r.Endpoint,
+		node:             node,
+		remote:           r.Remote,
+		vni:              r.VNI,
+		mcastGroup:       r.McastGroup,
+		mcastPort:        r.McastPort,
+		parentIndex:      parent.Attrs().Index,
+		ttl:              r.TTL,
+	}, nil
+}
+
+// LinkVXLAN represents a vxlan tunnel device stitched into a node's network namespace.
+type LinkVXLAN struct {
+	LinkCommonParams
+
+	endpoint *VXLANEndpoint
+	node     LinkNode
+
+	remote      string
+	vni         int
+	mcastGroup  string
+	mcastPort   int
+	parentIndex int
+	ttl         uint8
+}
+
+var _ LinkInterf = (*LinkVXLAN)(nil)
+
+// Deploy creates the vxlan device and pushes it into the node's network namespace.
+func (l *LinkVXLAN) Deploy(ctx context.Context) error {
+	port := l.mcastPort
+	if port == 0 {
+		port = defaultVXLANPort
+	}
+
+	vxlan := &netlink.Vxlan{
+		LinkAttrs: netlink.LinkAttrs{
+			Name: genRandomIfName(),
+			MTU:  l.Mtu,
+		},
+		VxlanId:      l.vni,
+		VtepDevIndex: l.parentIndex,
+		Port:         port,
+		TTL:          int(l.ttl),
+	}
+
+	var remoteIP net.IP
+
+	switch {
+	case l.mcastGroup != "":
+		// multicast learning mode: the kernel floods/learns over the multicast group.
+		vxlan.Group = net.ParseIP(l.mcastGroup)
+		if vxlan.Group == nil {
+			return fmt.Errorf("invalid vxlan mcast-group %q", l.mcastGroup)
+		}
+		vxlan.Learning = true
+	default:
+		// point-to-point mode: disable learning, FDB entry is pre-populated below.
+		remoteIP = net.ParseIP(l.remote)
+		if remoteIP == nil {
+			return fmt.Errorf("invalid vxlan remote %q", l.remote)
+		}
+		vxlan.Learning = false
+	}
+
+	if err := netlink.LinkAdd(vxlan); err != nil {
+		return fmt.Errorf("failed to create vxlan device: %w", err)
+	}
+
+	err := l.node.AddLink(ctx, vxlan, func(hostNS ns.NetNS) error {
+		if remoteIP != nil {
+			// a zero destination MAC acts as a catch-all fdb entry, so every
+			// frame the kernel doesn't otherwise have a more specific entry
+			// for gets encapsulated and sent to the remote VTEP.
+			err := netlink.NeighAppend(&netlink.Neigh{
+				LinkIndex:    vxlan.Index,
+				Family:       unix.AF_BRIDGE,
+				Flags:        netlink.NTF_SELF,
+				State:        netlink.NUD_PERMANENT,
+				IP:           remoteIP,
+				HardwareAddr: net.HardwareAddr{0, 0, 0, 0, 0, 0},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to pre-populate vxlan fdb entry for %s: %w", l.remote, err)
+			}
+		}
+		return SetNameMACAndUpInterface(vxlan, l.toEndpt())(hostNS)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deploy vxlan link: %w", err)
+	}
+
+	return nil
+}
+
+func (l *LinkVXLAN) toEndpt() *Endpt {
+	return &Endpt{
+		Iface:   l.endpoint.Iface,
+		Sysctls: l.Sysctls,
+	}
+}
+
+// GetType returns the type of the link.
+func (l *LinkVXLAN) GetType() LinkType {
+	return LinkTypeVXLAN
+}
+
+// Nodes returns the nodes this link touches, so LinkDeployer can serialize it
+// against other links sharing the same node.
+func (l *LinkVXLAN) Nodes() []string {
+	return []string{l.endpoint.Node}
+}
+
+var _ NodeLister = (*LinkVXLAN)(nil)
@@ -0,0 +1,31 @@
+package types
+
+import "fmt"
+
+func init() {
+	RegisterLinkDriver(vxlanLinkDriver{})
+}
+
+// vxlanLinkDriver registers the "vxlan" link kind with the link driver registry.
+type vxlanLinkDriver struct{}
+
+func (vxlanLinkDriver) Name() LinkType {
+	return LinkTypeVXLAN
+}
+
+func (vxlanLinkDriver) UnmarshalRaw(unmarshal func(interface{}) error) (RawLink, error) {
+	var l struct {
+		Type         string `yaml:"type"`
+		LinkVXLANRaw `yaml:",inline"`
+	}
+	if err := unmarshal(&l); err != nil {
+		return nil, err
+	}
+	return &l.LinkVXLANRaw, nil
+}
+
+// BriefFromLinkConfig is not supported: a vxlan link carries tunnel parameters
+// that cannot be expressed in the two-endpoint brief notation.
+func (vxlanLinkDriver) BriefFromLinkConfig(_ LinkConfig, _ int) (RawLink, error) {
+	return nil, fmt.Errorf("link type %q does not support brief notation, use the explicit form", LinkTypeVXLAN)
+}
@@ -0,0 +1,117 @@
+package types
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestLinkVXLANRaw_Resolve_Validation(t *testing.T) {
+	t.Cleanup(ResetNodeRegistry)
+	RegisterNode("n1", &fakeLinkNode{nsPath: "/proc/1/ns/net"})
+
+	cases := []struct {
+		name    string
+		raw     LinkVXLANRaw
+		wantErr string
+	}{
+		{
+			name:    "missing endpoint",
+			raw:     LinkVXLANRaw{},
+			wantErr: "requires an endpoint.node",
+		},
+		{
+			name:    "empty endpoint node",
+			raw:     LinkVXLANRaw{Endpoint: &VXLANEndpoint{}},
+			wantErr: "requires an endpoint.node",
+		},
+		{
+			name: "neither remote nor mcast-group set",
+			raw: LinkVXLANRaw{
+				Endpoint:        &VXLANEndpoint{Node: "n1", Iface: "vx0"},
+				ParentInterface: "eth0",
+			},
+			wantErr: "requires either remote or mcast-group",
+		},
+		{
+			name: "both remote and mcast-group set",
+			raw: LinkVXLANRaw{
+				Endpoint:        &VXLANEndpoint{Node: "n1", Iface: "vx0"},
+				Remote:          "192.0.2.1",
+				McastGroup:      "239.1.1.1",
+				ParentInterface: "eth0",
+			},
+			wantErr: "cannot set both remote and mcast-group",
+		},
+		{
+			name: "missing parent-interface",
+			raw: LinkVXLANRaw{
+				Endpoint: &VXLANEndpoint{Node: "n1", Iface: "vx0"},
+				Remote:   "192.0.2.1",
+			},
+			wantErr: "requires a parent-interface",
+		},
+		{
+			name: "missing endpoint.iface",
+			raw: LinkVXLANRaw{
+				Endpoint:        &VXLANEndpoint{Node: "n1"},
+				Remote:          "192.0.2.1",
+				ParentInterface: "eth0",
+			},
+			wantErr: "requires an endpoint.iface",
+		},
+		{
+			name: "unregistered node",
+			raw: LinkVXLANRaw{
+				Endpoint:        &VXLANEndpoint{Node: "does-not-exist", Iface: "vx0"},
+				Remote:          "192.0.2.1",
+				ParentInterface: "eth0",
+			},
+			wantErr: "not found in node registry",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := c.raw.Resolve()
+			if err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+			if !strings.Contains(err.Error(), c.wantErr) {
+				t.Fatalf("error %q does not contain %q", err.Error(), c.wantErr)
+			}
+		})
+	}
+}
+
+func TestLinkVXLAN_Deploy_RejectsInvalidRemote(t *testing.T) {
+	l := &LinkVXLAN{
+		endpoint: &VXLANEndpoint{Node: "n1", Iface: "vx0"},
+		node:     &fakeLinkNode{nsPath: "/proc/1/ns/net"},
+		remote:   "not-an-ip",
+	}
+
+	err := l.Deploy(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid vxlan remote") {
+		t.Fatalf("error %q does not mention the invalid remote", err.Error())
+	}
+}
+
+func TestLinkVXLAN_Deploy_RejectsInvalidMcastGroup(t *testing.T) {
+	l := &LinkVXLAN{
+		endpoint:   &VXLANEndpoint{Node: "n1", Iface: "vx0"},
+		node:       &fakeLinkNode{nsPath: "/proc/1/ns/net"},
+		mcastGroup: "not-an-ip",
+	}
+
+	err := l.Deploy(context.Background())
+	if err == nil {
+		t.Fatalf("expected an error, got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid vxlan mcast-group") {
+		t.Fatalf("error %q does not mention the invalid mcast-group", err.Error())
+	}
+}